@@ -8,20 +8,84 @@ import (
 
 // NewContainer makes new container
 func NewContainer() *internal.Container {
-	return &internal.Container{}
+	c := internal.NewContainer()
+	return &c
 }
 
 // A default instance for container
 var container *internal.Container = NewContainer()
 
 // Singleton creates a singleton for the default instance.
-func Singleton(resolver interface{}) {
-	container.Singleton(resolver)
+func Singleton(resolver interface{}) error {
+	return container.Singleton(resolver)
+}
+
+// MustSingleton is like Singleton but panics if the binding fails.
+func MustSingleton(resolver interface{}) {
+	if err := Singleton(resolver); err != nil {
+		panic(err)
+	}
 }
 
 // Transient creates a transient binding for the default instance.
-func Transient(resolver interface{}) {
-	container.Transient(resolver)
+func Transient(resolver interface{}) error {
+	return container.Transient(resolver)
+}
+
+// MustTransient is like Transient but panics if the binding fails.
+func MustTransient(resolver interface{}) {
+	if err := Transient(resolver); err != nil {
+		panic(err)
+	}
+}
+
+// NamedSingleton creates a singleton binding, stored under name, for the default instance.
+func NamedSingleton(name string, resolver interface{}) error {
+	return container.NamedSingleton(name, resolver)
+}
+
+// MustNamedSingleton is like NamedSingleton but panics if the binding fails.
+func MustNamedSingleton(name string, resolver interface{}) {
+	if err := NamedSingleton(name, resolver); err != nil {
+		panic(err)
+	}
+}
+
+// NamedTransient creates a transient binding, stored under name, for the default instance.
+func NamedTransient(name string, resolver interface{}) error {
+	return container.NamedTransient(name, resolver)
+}
+
+// MustNamedTransient is like NamedTransient but panics if the binding fails.
+func MustNamedTransient(name string, resolver interface{}) {
+	if err := NamedTransient(name, resolver); err != nil {
+		panic(err)
+	}
+}
+
+// SingletonLazy creates a lazy singleton binding for the default instance. The resolver is
+// invoked once, on first Make of the abstraction, instead of at bind time.
+func SingletonLazy(resolver interface{}) error {
+	return container.SingletonLazy(resolver)
+}
+
+// MustSingletonLazy is like SingletonLazy but panics if the binding fails.
+func MustSingletonLazy(resolver interface{}) {
+	if err := SingletonLazy(resolver); err != nil {
+		panic(err)
+	}
+}
+
+// NamedSingletonLazy creates a lazy singleton binding, stored under name, for the default instance.
+func NamedSingletonLazy(name string, resolver interface{}) error {
+	return container.NamedSingletonLazy(name, resolver)
+}
+
+// MustNamedSingletonLazy is like NamedSingletonLazy but panics if the binding fails.
+func MustNamedSingletonLazy(name string, resolver interface{}) {
+	if err := NamedSingletonLazy(name, resolver); err != nil {
+		panic(err)
+	}
 }
 
 // Reset removes all bindings in the default instance.
@@ -30,6 +94,50 @@ func Reset() {
 }
 
 // Make binds receiver to the default instance.
-func Make(receiver interface{}) {
-	container.Make(receiver)
+func Make(receiver interface{}) error {
+	return container.Make(receiver)
+}
+
+// MustMake is like Make but panics if the resolution fails.
+func MustMake(receiver interface{}) {
+	if err := Make(receiver); err != nil {
+		panic(err)
+	}
+}
+
+// MakeNamed binds the named binding of receiver's abstraction to the default instance.
+func MakeNamed(name string, receiver interface{}) error {
+	return container.MakeNamed(name, receiver)
+}
+
+// MustMakeNamed is like MakeNamed but panics if the resolution fails.
+func MustMakeNamed(name string, receiver interface{}) {
+	if err := MakeNamed(name, receiver); err != nil {
+		panic(err)
+	}
+}
+
+// Fill populates structPtr's exported fields from the default instance; see Container.Fill
+// for the `container` struct tag rules.
+func Fill(structPtr interface{}) error {
+	return container.Fill(structPtr)
+}
+
+// MustFill is like Fill but panics if a required field cannot be resolved.
+func MustFill(structPtr interface{}) {
+	if err := Fill(structPtr); err != nil {
+		panic(err)
+	}
+}
+
+// Call invokes function, resolving its arguments from the default instance.
+func Call(function interface{}) error {
+	return container.Call(function)
+}
+
+// MustCall is like Call but panics if the invocation fails.
+func MustCall(function interface{}) {
+	if err := Call(function); err != nil {
+		panic(err)
+	}
 }
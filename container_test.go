@@ -0,0 +1,129 @@
+package ioccontainer
+
+import "testing"
+
+type greeter interface {
+	Greet() string
+}
+
+type helloGreeter struct{}
+
+func (helloGreeter) Greet() string { return "hello" }
+
+func TestSingleton_ReturnsErrorForInvalidResolver(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := Singleton("not a function"); err == nil {
+		t.Fatal("Singleton: expected an error for a non-function resolver, got nil")
+	}
+}
+
+func TestTransient_ReturnsErrorForInvalidResolver(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := Transient("not a function"); err == nil {
+		t.Fatal("Transient: expected an error for a non-function resolver, got nil")
+	}
+}
+
+func TestMake_ReturnsErrorForInvalidReceiver(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := Make("not a pointer"); err == nil {
+		t.Fatal("Make: expected an error for a non-reference receiver, got nil")
+	}
+}
+
+func TestMake_ResolvesBoundSingleton(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := Singleton(func() greeter { return helloGreeter{} }); err != nil {
+		t.Fatalf("Singleton: %v", err)
+	}
+
+	var g greeter
+	if err := Make(&g); err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got, want := g.Greet(), "hello"; got != want {
+		t.Fatalf("Greet() = %q, want %q", got, want)
+	}
+}
+
+func TestMustSingleton_PanicsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustSingleton: expected a panic for a non-function resolver")
+		}
+	}()
+	MustSingleton("not a function")
+}
+
+func TestMustTransient_PanicsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustTransient: expected a panic for a non-function resolver")
+		}
+	}()
+	MustTransient("not a function")
+}
+
+func TestMustMake_PanicsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustMake: expected a panic for an unbound abstraction")
+		}
+	}()
+	var g greeter
+	MustMake(&g)
+}
+
+func TestMustSingletonLazy_PanicsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustSingletonLazy: expected a panic for a non-function resolver")
+		}
+	}()
+	MustSingletonLazy("not a function")
+}
+
+func TestMustCall_PanicsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustCall: expected a panic for an unresolvable argument")
+		}
+	}()
+	MustCall(func(g greeter) {})
+}
+
+func TestMustSingleton_DoesNotPanicOnSuccess(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	MustSingleton(func() greeter { return helloGreeter{} })
+
+	var g greeter
+	MustMake(&g)
+	if got, want := g.Greet(), "hello"; got != want {
+		t.Fatalf("Greet() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,416 @@
+// Package container provides an IoC container for Go projects.
+// It provides simple, fluent and easy-to-use interface to make dependency injection in GoLang easier.
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// defaultBindingName is the map key used for the unnamed (default) binding of an abstraction.
+const defaultBindingName = ""
+
+// binding keeps a binding resolver and instance (for singleton bindings).
+type binding struct {
+	resolver interface{}  // resolver function
+	instance interface{}  // instance stored for eager singleton bindings
+	lazy     *lazyBinding // set for lazy singleton bindings, nil otherwise
+}
+
+// lazyBinding defers resolver invocation until the binding is first resolved, caching the
+// result (or error) behind a sync.Once so later resolves are free and concurrency-safe.
+type lazyBinding struct {
+	once     sync.Once
+	instance interface{}
+	err      error
+}
+
+// resolve will return the concrete of related abstraction. path carries the chain of
+// abstractions already being resolved, so reentrant cycles can be detected instead of
+// recursing forever.
+func (b binding) resolve(c Container, path resolutionPath) (interface{}, error) {
+	if b.instance != nil {
+		return b.instance, nil
+	}
+
+	if b.lazy != nil {
+		b.lazy.once.Do(func() {
+			b.lazy.instance, b.lazy.err = c.invoke(b.resolver, path)
+		})
+		return b.lazy.instance, b.lazy.err
+	}
+
+	return c.invoke(b.resolver, path)
+}
+
+// resolutionStep identifies a single binding being resolved: its abstraction type and the
+// binding name (the empty string for the default, unnamed binding). Two different bindings
+// of the same abstraction (e.g. a named decorator resolving the default binding of its own
+// type) are distinct steps and must not trip cycle detection against one another.
+type resolutionStep struct {
+	abstraction reflect.Type
+	name        string
+}
+
+// resolutionPath is the chain of bindings currently being resolved, in resolution order.
+// It is used to detect circular dependencies between resolvers.
+type resolutionPath []resolutionStep
+
+// push returns a copy of path with (abstraction, name) appended, leaving path itself untouched.
+func (path resolutionPath) push(abstraction reflect.Type, name string) resolutionPath {
+	next := make(resolutionPath, len(path)+1)
+	copy(next, path)
+	next[len(path)] = resolutionStep{abstraction: abstraction, name: name}
+	return next
+}
+
+// contains reports whether (abstraction, name) is already part of the path, i.e. that exact
+// binding is being resolved as a (possibly indirect) dependency of itself.
+func (path resolutionPath) contains(abstraction reflect.Type, name string) bool {
+	for _, step := range path {
+		if step.abstraction == abstraction && step.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the path as "A -> B -> C" for circular dependency error messages.
+func (path resolutionPath) String() string {
+	names := make([]string, len(path))
+	for i, step := range path {
+		names[i] = step.abstraction.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Container holds bindings, keyed by reflect.Type and then by binding name (the empty string
+// is the default, unnamed binding). A container may have a parent: any abstraction not bound
+// locally is resolved by walking up the parent chain, which lets a Sub container scope or
+// override bindings (e.g. per HTTP request or per test) without mutating its parent.
+type Container struct {
+	bindings map[reflect.Type]map[string]binding
+	parent   *Container
+}
+
+// NewContainer returns a new instance of Container
+func NewContainer() Container {
+	return Container{bindings: make(map[reflect.Type]map[string]binding)}
+}
+
+// Sub returns a new child container that falls back to c for any abstraction not bound
+// locally. Bindings registered on the child are invisible to c, and a child binding for an
+// abstraction already bound on c overrides it for that child's scope.
+func (c Container) Sub() Container {
+	parent := c
+	return Container{bindings: make(map[reflect.Type]map[string]binding), parent: &parent}
+}
+
+// bind will map an abstraction to a concrete under the given name. Eager singleton bindings
+// invoke the resolver immediately; lazy singleton bindings defer invocation until first resolve.
+func (c Container) bind(name string, resolver interface{}, singleton bool, lazy bool) error {
+	resolverTypeOf := reflect.TypeOf(resolver)
+	if resolverTypeOf.Kind() != reflect.Func {
+		return fmt.Errorf("The resolver must be a function")
+	}
+
+	for i := 0; i < resolverTypeOf.NumOut(); i++ {
+		b := binding{resolver: resolver}
+		abstraction := resolverTypeOf.Out(i)
+
+		if singleton {
+			if lazy {
+				b.lazy = &lazyBinding{}
+			} else {
+				instance, err := c.invoke(resolver, resolutionPath{{abstraction: abstraction, name: name}})
+				if err != nil {
+					return err
+				}
+				b.instance = instance
+			}
+		}
+
+		if c.bindings[abstraction] == nil {
+			c.bindings[abstraction] = make(map[string]binding)
+		}
+		c.bindings[abstraction][name] = b
+	}
+	return nil
+}
+
+// invoke will call the given function and return its returned value.
+// It only works for functions that return a single value.
+func (c Container) invoke(function interface{}, path resolutionPath) (interface{}, error) {
+	reflectValue, err := c.arguments(function, path)
+	if err != nil {
+		return struct{}{}, err
+	}
+
+	return reflect.ValueOf(function).Call(reflectValue)[0].Interface(), nil
+}
+
+// arguments will return resolved arguments of the given function.
+// Arguments are always resolved against the default (unnamed) binding of their type.
+func (c Container) arguments(function interface{}, path resolutionPath) ([]reflect.Value, error) {
+	functionTypeOf := reflect.TypeOf(function)
+	argumentsCount := functionTypeOf.NumIn()
+	arguments := make([]reflect.Value, argumentsCount)
+
+	for i := 0; i < argumentsCount; i++ {
+		abstraction := functionTypeOf.In(i)
+
+		instance, err := c.resolveByType(abstraction, defaultBindingName, path)
+		if err != nil {
+			return []reflect.Value{}, err
+		}
+
+		arguments[i] = reflect.ValueOf(instance)
+	}
+
+	return arguments, nil
+}
+
+// resolveByType resolves the binding registered for abstraction under name, checking this
+// container's own bindings first and then walking up the parent chain. path is the chain of
+// bindings already being resolved; re-entering the same (abstraction, name) pair means a
+// circular dependency. Distinct bindings of the same abstraction (e.g. a named decorator
+// resolving the default binding of its own type) are not considered circular.
+func (c Container) resolveByType(abstraction reflect.Type, name string, path resolutionPath) (interface{}, error) {
+	if path.contains(abstraction, name) {
+		return nil, fmt.Errorf("circular dependency detected: %s", path.push(abstraction, name))
+	}
+
+	if bindingsByName, ok := c.bindings[abstraction]; ok {
+		if concrete, ok := bindingsByName[name]; ok {
+			return concrete.resolve(c, path.push(abstraction, name))
+		}
+	}
+
+	if c.parent != nil {
+		return c.parent.resolveByType(abstraction, name, path)
+	}
+
+	if name == defaultBindingName {
+		return nil, fmt.Errorf("No concrete found for the abstraction: " + abstraction.String())
+	}
+	return nil, fmt.Errorf("No concrete found for the abstraction %s with name %q", abstraction.String(), name)
+}
+
+// Singleton will bind an abstraction to a concrete for further singleton resolves.
+// It takes a resolver function which returns the concrete and its return type matches the abstraction (interface).
+// The resolver function can have arguments of abstraction that have bound already in Container.
+func (c Container) Singleton(resolver interface{}) error {
+	return c.bind(defaultBindingName, resolver, true, false)
+}
+
+// NamedSingleton will bind an abstraction to a concrete, stored under name, for further singleton resolves.
+// It lets callers register several concretes of the same abstraction (e.g. "primary" and "replica" Databases)
+// and select between them with MakeNamed at resolve time.
+func (c Container) NamedSingleton(name string, resolver interface{}) error {
+	return c.bind(name, resolver, true, false)
+}
+
+// SingletonLazy will bind an abstraction to a concrete for further singleton resolves, without invoking
+// the resolver right away. For a resolver with a single return value, the resolver is invoked once, on
+// the first Make of the abstraction, and the resulting instance is cached for later resolves. This lets
+// bindings be registered in any order and avoids paying the resolver's cost for services that end up
+// unused. If the resolver returns more than one type, each output type gets its own independent
+// invocation and cache (matching Singleton's existing per-output behavior), so the resolver runs once
+// per bound output rather than once overall.
+func (c Container) SingletonLazy(resolver interface{}) error {
+	return c.bind(defaultBindingName, resolver, true, true)
+}
+
+// NamedSingletonLazy is the named variant of SingletonLazy.
+func (c Container) NamedSingletonLazy(name string, resolver interface{}) error {
+	return c.bind(name, resolver, true, true)
+}
+
+// Transient will bind an abstraction to a concrete for further transient resolves.
+// It takes a resolver function which returns the concrete and its return type matches the abstraction (interface).
+// The resolver function can have arguments of abstraction that have bound already in Container.
+func (c Container) Transient(resolver interface{}) error {
+	return c.bind(defaultBindingName, resolver, false, false)
+}
+
+// NamedTransient will bind an abstraction to a concrete, stored under name, for further transient resolves.
+func (c Container) NamedTransient(name string, resolver interface{}) error {
+	return c.bind(name, resolver, false, false)
+}
+
+// Reset will remove all bindings from this container's own scope. Bindings inherited from a
+// parent (see Sub) are left untouched.
+func (c Container) Reset() {
+	for k := range c.bindings {
+		delete(c.bindings, k)
+	}
+}
+
+// Make will resolve the dependency and return a appropriate concrete of the given abstraction.
+// It can take an abstraction (interface reference) and fill it with the related implementation.
+// It also can takes a function (receiver) with one or more arguments of the abstractions (interfaces) that need to be
+// resolved, Container will invoke the receiver function and pass the related implementations.
+// If receiver is a pointer to a struct, it is resolved field by field; see Fill for the tag rules.
+func (c Container) Make(receiver interface{}) error {
+	receiverTypeOf := reflect.TypeOf(receiver)
+	if receiverTypeOf == nil {
+		return fmt.Errorf("cannot detect type of the receiver, make sure your are passing reference of the object")
+	}
+
+	if receiverTypeOf.Kind() == reflect.Ptr {
+		return c.makePtr(receiver, receiverTypeOf)
+	}
+
+	if receiverTypeOf.Kind() == reflect.Func {
+		return c.Call(receiver)
+	}
+
+	return fmt.Errorf("the receiver must be either a reference or a callback")
+}
+
+// Call invokes function, resolving each of its arguments from the container. If function's
+// last return value is of an error type, Call returns it; other return values are ignored.
+func (c Container) Call(function interface{}) error {
+	functionTypeOf := reflect.TypeOf(function)
+	if functionTypeOf == nil || functionTypeOf.Kind() != reflect.Func {
+		return fmt.Errorf("the receiver must be either a reference or a callback")
+	}
+
+	return c.makeFunc(function, functionTypeOf)
+}
+
+// MakeNamed resolves the named binding of the abstraction pointed to by receiver.
+// receiver must be a pointer to the abstraction (interface or concrete type).
+func (c Container) MakeNamed(name string, receiver interface{}) error {
+	receiverTypeOf := reflect.TypeOf(receiver)
+	if receiverTypeOf == nil {
+		return fmt.Errorf("cannot detect type of the receiver, make sure your are passing reference of the object")
+	}
+
+	if receiverTypeOf.Kind() != reflect.Ptr {
+		return fmt.Errorf("the receiver must be a reference")
+	}
+
+	abstraction := receiverTypeOf.Elem()
+	instance, err := c.resolveByType(abstraction, name, nil)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(receiver).Elem().Set(reflect.ValueOf(instance))
+	return nil
+}
+
+func (c Container) makePtr(receiver interface{}, receiverTypeOf reflect.Type) error {
+	abstraction := receiverTypeOf.Elem()
+
+	if abstraction.Kind() == reflect.Struct {
+		return c.Fill(receiver)
+	}
+
+	instance, err := c.resolveByType(abstraction, defaultBindingName, nil)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(receiver).Elem().Set(reflect.ValueOf(instance))
+	return nil
+}
+
+// Fill takes a pointer to a struct and populates each exported field whose type has a matching
+// binding. The `container` struct tag controls how a field is resolved; any other tag value is
+// rejected as invalid rather than silently falling back to the default binding:
+//
+//	container:"type"      resolve the default binding of the field's type (the default behavior)
+//	container:"name=foo"  resolve the binding named "foo" for the field's type
+//	container:"ignore"    skip the field entirely
+//	container:"optional"  resolve the default binding, but leave the field untouched if missing
+//
+// A bare binding name used as the tag value (e.g. `container:"primary"`) is one of these
+// invalid values; a named binding is selected with `container:"name=primary"`.
+//
+// Fields that are required but have no matching binding, and fields with an invalid tag, are
+// collected and reported together in a single error.
+func (c Container) Fill(structPtr interface{}) error {
+	structPtrTypeOf := reflect.TypeOf(structPtr)
+	if structPtrTypeOf == nil || structPtrTypeOf.Kind() != reflect.Ptr || structPtrTypeOf.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Fill: structPtr must be a pointer to a struct")
+	}
+
+	structTypeOf := structPtrTypeOf.Elem()
+	structValue := reflect.ValueOf(structPtr).Elem()
+
+	var unresolved []string
+	var invalidTags []string
+	for i := 0; i < structTypeOf.NumField(); i++ {
+		field := structTypeOf.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported field
+		}
+
+		tag := field.Tag.Get("container")
+		name := defaultBindingName
+		optional := false
+
+		switch {
+		case tag == "ignore":
+			continue
+		case tag == "" || tag == "type":
+			// resolve the default binding, required
+		case tag == "optional":
+			optional = true
+		case strings.HasPrefix(tag, "name="):
+			name = strings.TrimPrefix(tag, "name=")
+		default:
+			invalidTags = append(invalidTags, fmt.Sprintf("%s.%s (tag %q)", structTypeOf.Name(), field.Name, tag))
+			continue
+		}
+
+		instance, err := c.resolveByType(field.Type, name, nil)
+		if err != nil {
+			if optional {
+				continue
+			}
+			unresolved = append(unresolved, structTypeOf.Name()+"."+field.Name)
+			continue
+		}
+		structValue.Field(i).Set(reflect.ValueOf(instance))
+	}
+
+	var problems []string
+	if len(invalidTags) > 0 {
+		problems = append(problems, "invalid `container` tag on field(s): "+strings.Join(invalidTags, ", "))
+	}
+	if len(unresolved) > 0 {
+		problems = append(problems, "unresolved required fields: "+strings.Join(unresolved, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("container: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (c Container) makeFunc(receiver interface{}, receiverTypeOf reflect.Type) error {
+	arguments, err := c.arguments(receiver, nil)
+	if err != nil {
+		return err
+	}
+	returnedValues := reflect.ValueOf(receiver).Call(arguments)
+	return returnLastReflectValueIfError(returnedValues)
+}
+
+func returnLastReflectValueIfError(values []reflect.Value) error {
+	if len(values) == 0 {
+		return nil
+	}
+	lastValue := values[len(values)-1]
+
+	errorInterface := reflect.TypeOf((*error)(nil)).Elem()
+	if lastValue.Type().Kind() == reflect.Interface && lastValue.Type().Implements(errorInterface) {
+		if lastValue.IsNil() {
+			return nil
+		}
+		return lastValue.Interface().(error)
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type plainGreeter struct{}
+
+func (plainGreeter) Greet() string { return "hi" }
+
+type loudGreeter struct{ inner greeter }
+
+func (l loudGreeter) Greet() string { return strings.ToUpper(l.inner.Greet()) }
+
+func TestResolveByType_NamedDecoratorIsNotCircular(t *testing.T) {
+	c := NewContainer()
+
+	if err := c.SingletonLazy(func() greeter { return plainGreeter{} }); err != nil {
+		t.Fatalf("bind default greeter: %v", err)
+	}
+	if err := c.NamedSingletonLazy("decorated", func(g greeter) greeter { return loudGreeter{inner: g} }); err != nil {
+		t.Fatalf("bind decorated greeter: %v", err)
+	}
+
+	var g greeter
+	if err := c.MakeNamed("decorated", &g); err != nil {
+		t.Fatalf("MakeNamed(\"decorated\"): %v", err)
+	}
+	if got, want := g.Greet(), "HI"; got != want {
+		t.Fatalf("Greet() = %q, want %q", got, want)
+	}
+}
+
+type cycleA interface{ A() }
+type cycleB interface{ B() }
+type cycleAImpl struct{ b cycleB }
+
+func (cycleAImpl) A() {}
+
+type cycleBImpl struct{ a cycleA }
+
+func (cycleBImpl) B() {}
+
+func TestResolveByType_DetectsCircularDependency(t *testing.T) {
+	c := NewContainer()
+
+	if err := c.SingletonLazy(func(b cycleB) cycleA { return cycleAImpl{b: b} }); err != nil {
+		t.Fatalf("bind cycleA: %v", err)
+	}
+	if err := c.SingletonLazy(func(a cycleA) cycleB { return cycleBImpl{a: a} }); err != nil {
+		t.Fatalf("bind cycleB: %v", err)
+	}
+
+	var a cycleA
+	err := c.Make(&a)
+	if err == nil {
+		t.Fatal("Make(&a) = nil error, want circular dependency error")
+	}
+	if !strings.Contains(err.Error(), "circular dependency detected") {
+		t.Fatalf("Make(&a) error = %q, want it to mention a circular dependency", err.Error())
+	}
+}
+
+type filledStruct struct {
+	Default  greeter `container:"type"`
+	Implicit greeter
+	Named    greeter `container:"name=decorated"`
+	Ignored  greeter `container:"ignore"`
+	Optional greeter `container:"optional"`
+	private  greeter
+}
+
+func TestFill_TagModes(t *testing.T) {
+	c := NewContainer()
+	if err := c.Singleton(func() greeter { return plainGreeter{} }); err != nil {
+		t.Fatalf("bind default greeter: %v", err)
+	}
+	if err := c.NamedSingleton("decorated", func() greeter { return loudGreeter{inner: plainGreeter{}} }); err != nil {
+		t.Fatalf("bind named greeter: %v", err)
+	}
+
+	var s filledStruct
+	if err := c.Fill(&s); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	if s.Default == nil || s.Default.Greet() != "hi" {
+		t.Errorf("Default field not resolved to the default binding")
+	}
+	if s.Implicit == nil || s.Implicit.Greet() != "hi" {
+		t.Errorf("untagged field not resolved to the default binding")
+	}
+	if s.Named == nil || s.Named.Greet() != "HI" {
+		t.Errorf("Named field not resolved to the \"decorated\" binding")
+	}
+	if s.Ignored != nil {
+		t.Errorf("Ignored field should be left untouched, got %v", s.Ignored)
+	}
+	if s.Optional == nil || s.Optional.Greet() != "hi" {
+		t.Errorf("Optional field should resolve the default binding when one exists")
+	}
+	if s.private != nil {
+		t.Errorf("unexported field should never be touched")
+	}
+}
+
+type optionalMissingStruct struct {
+	Optional greeter `container:"optional"`
+}
+
+func TestFill_OptionalFieldLeftUntouchedWhenMissing(t *testing.T) {
+	c := NewContainer()
+
+	var s optionalMissingStruct
+	if err := c.Fill(&s); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if s.Optional != nil {
+		t.Errorf("Optional field has no binding and should be left untouched, got %v", s.Optional)
+	}
+}
+
+type requiredMissingStruct struct {
+	Missing greeter
+}
+
+func TestFill_MissingRequiredFieldErrors(t *testing.T) {
+	c := NewContainer()
+
+	var s requiredMissingStruct
+	err := c.Fill(&s)
+	if err == nil {
+		t.Fatal("Fill = nil error, want an error listing the unresolved field")
+	}
+	if !strings.Contains(err.Error(), "requiredMissingStruct.Missing") {
+		t.Fatalf("Fill error = %q, want it to name the unresolved field", err.Error())
+	}
+}
+
+type invalidTagStruct struct {
+	Broken greeter `container:"primary"`
+}
+
+func TestFill_InvalidTagErrors(t *testing.T) {
+	c := NewContainer()
+	if err := c.Singleton(func() greeter { return plainGreeter{} }); err != nil {
+		t.Fatalf("bind default greeter: %v", err)
+	}
+
+	var s invalidTagStruct
+	err := c.Fill(&s)
+	if err == nil {
+		t.Fatal("Fill = nil error, want an error for the unrecognized container tag")
+	}
+	if !strings.Contains(err.Error(), "Broken") || !strings.Contains(err.Error(), "primary") {
+		t.Fatalf("Fill error = %q, want it to name the field and the invalid tag value", err.Error())
+	}
+	if s.Broken != nil {
+		t.Fatalf("Broken field should be left untouched when its tag is invalid")
+	}
+}
+
+type counterDB struct{ n int }
+
+func TestSingletonLazy_DefersInvocationUntilFirstMake(t *testing.T) {
+	c := NewContainer()
+	calls := 0
+
+	if err := c.SingletonLazy(func() *counterDB { calls++; return &counterDB{n: calls} }); err != nil {
+		t.Fatalf("SingletonLazy: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("calls = %d after SingletonLazy, want 0 (resolver must not run until first Make)", calls)
+	}
+
+	var first *counterDB
+	if err := c.Make(&first); err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d after first Make, want 1", calls)
+	}
+
+	var second *counterDB
+	if err := c.Make(&second); err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d after second Make, want 1 (resolver must run once)", calls)
+	}
+	if first != second {
+		t.Fatalf("Make returned different instances across calls, want the same cached singleton")
+	}
+}
+
+func TestNamedSingletonLazy_DefersInvocationUntilFirstMakeNamed(t *testing.T) {
+	c := NewContainer()
+	calls := 0
+
+	if err := c.NamedSingletonLazy("counted", func() *counterDB { calls++; return &counterDB{n: calls} }); err != nil {
+		t.Fatalf("NamedSingletonLazy: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("calls = %d after NamedSingletonLazy, want 0", calls)
+	}
+
+	var first *counterDB
+	if err := c.MakeNamed("counted", &first); err != nil {
+		t.Fatalf("MakeNamed: %v", err)
+	}
+	var second *counterDB
+	if err := c.MakeNamed("counted", &second); err != nil {
+		t.Fatalf("MakeNamed: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1 (resolver must run once and be cached)", calls)
+	}
+	if first != second {
+		t.Fatalf("MakeNamed returned different instances across calls, want the same cached singleton")
+	}
+}
+
+type database interface {
+	DSN() string
+}
+
+type primaryDB struct{}
+
+func (primaryDB) DSN() string { return "primary" }
+
+type replicaDB struct{}
+
+func (replicaDB) DSN() string { return "replica" }
+
+func TestNamedSingleton_DisambiguatesConcretesOfTheSameAbstraction(t *testing.T) {
+	c := NewContainer()
+
+	if err := c.NamedSingleton("primary", func() database { return primaryDB{} }); err != nil {
+		t.Fatalf("NamedSingleton(primary): %v", err)
+	}
+	if err := c.NamedSingleton("replica", func() database { return replicaDB{} }); err != nil {
+		t.Fatalf("NamedSingleton(replica): %v", err)
+	}
+
+	var primary database
+	if err := c.MakeNamed("primary", &primary); err != nil {
+		t.Fatalf("MakeNamed(primary): %v", err)
+	}
+	if got, want := primary.DSN(), "primary"; got != want {
+		t.Fatalf("MakeNamed(primary) DSN = %q, want %q", got, want)
+	}
+
+	var replica database
+	if err := c.MakeNamed("replica", &replica); err != nil {
+		t.Fatalf("MakeNamed(replica): %v", err)
+	}
+	if got, want := replica.DSN(), "replica"; got != want {
+		t.Fatalf("MakeNamed(replica) DSN = %q, want %q", got, want)
+	}
+
+	// No unnamed binding was registered, so the default Make must fail rather than
+	// silently picking one of the named concretes.
+	var d database
+	if err := c.Make(&d); err == nil {
+		t.Fatalf("Make() = nil error, want an error since only named bindings exist")
+	}
+}
+
+func TestNamedTransient_ProducesDistinctNamedInstances(t *testing.T) {
+	c := NewContainer()
+
+	type widget struct{ n int }
+	n := 0
+	if err := c.NamedTransient("a", func() *widget { n++; return &widget{n: n} }); err != nil {
+		t.Fatalf("NamedTransient(a): %v", err)
+	}
+	if err := c.NamedTransient("b", func() *widget { n += 100; return &widget{n: n} }); err != nil {
+		t.Fatalf("NamedTransient(b): %v", err)
+	}
+
+	var a1, a2, b1 *widget
+	if err := c.MakeNamed("a", &a1); err != nil {
+		t.Fatalf("MakeNamed(a): %v", err)
+	}
+	if err := c.MakeNamed("a", &a2); err != nil {
+		t.Fatalf("MakeNamed(a): %v", err)
+	}
+	if err := c.MakeNamed("b", &b1); err != nil {
+		t.Fatalf("MakeNamed(b): %v", err)
+	}
+
+	if a1 == a2 {
+		t.Fatalf("transient binding \"a\" returned the same instance twice, want a fresh one per resolve")
+	}
+	if b1.n == a1.n || b1.n == a2.n {
+		t.Fatalf("binding \"b\" resolved to a value from binding \"a\"'s resolver")
+	}
+}
+
+func TestSub_FallsBackToParentBinding(t *testing.T) {
+	parent := NewContainer()
+	if err := parent.Singleton(func() database { return primaryDB{} }); err != nil {
+		t.Fatalf("Singleton: %v", err)
+	}
+
+	child := parent.Sub()
+
+	var d database
+	if err := child.Make(&d); err != nil {
+		t.Fatalf("child.Make: %v", err)
+	}
+	if got, want := d.DSN(), "primary"; got != want {
+		t.Fatalf("child.Make resolved DSN = %q, want %q (inherited from parent)", got, want)
+	}
+}
+
+func TestSub_OverridesParentBindingWithoutMutatingIt(t *testing.T) {
+	parent := NewContainer()
+	if err := parent.Singleton(func() database { return primaryDB{} }); err != nil {
+		t.Fatalf("Singleton: %v", err)
+	}
+
+	child := parent.Sub()
+	if err := child.Singleton(func() database { return replicaDB{} }); err != nil {
+		t.Fatalf("child.Singleton: %v", err)
+	}
+
+	var fromChild database
+	if err := child.Make(&fromChild); err != nil {
+		t.Fatalf("child.Make: %v", err)
+	}
+	if got, want := fromChild.DSN(), "replica"; got != want {
+		t.Fatalf("child.Make DSN = %q, want %q (child override)", got, want)
+	}
+
+	var fromParent database
+	if err := parent.Make(&fromParent); err != nil {
+		t.Fatalf("parent.Make: %v", err)
+	}
+	if got, want := fromParent.DSN(), "primary"; got != want {
+		t.Fatalf("parent.Make DSN = %q, want %q (parent must be unaffected by child override)", got, want)
+	}
+}
+
+func TestSub_ResetOnlyClearsChildScope(t *testing.T) {
+	parent := NewContainer()
+	if err := parent.Singleton(func() database { return primaryDB{} }); err != nil {
+		t.Fatalf("Singleton: %v", err)
+	}
+
+	child := parent.Sub()
+	if err := child.Singleton(func() database { return replicaDB{} }); err != nil {
+		t.Fatalf("child.Singleton: %v", err)
+	}
+
+	child.Reset()
+
+	var afterReset database
+	if err := child.Make(&afterReset); err != nil {
+		t.Fatalf("child.Make after Reset: %v", err)
+	}
+	if got, want := afterReset.DSN(), "primary"; got != want {
+		t.Fatalf("child.Make after Reset DSN = %q, want %q (should fall back to parent again)", got, want)
+	}
+
+	var parentStillBound database
+	if err := parent.Make(&parentStillBound); err != nil {
+		t.Fatalf("parent.Make after child.Reset: %v (parent binding must survive child's Reset)", err)
+	}
+}